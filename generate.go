@@ -0,0 +1,464 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	pipepinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/urfave/cli/v2"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/resmap"
+	"sigs.k8s.io/kustomize/api/resource"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+func generateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "generate",
+		Usage: "generate markdown documentation from Tekton resources",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "output-dir",
+				Value: "taskmd.out",
+				Usage: "directory to write generated markdown files to",
+			},
+			&cli.StringFlag{
+				Name:  "format",
+				Value: "markdown",
+				Usage: "output format; only markdown is implemented, any other value is rejected",
+			},
+			&cli.StringSliceFlag{
+				Name:  "include-kinds",
+				Value: cli.NewStringSlice("Task", "Pipeline", "ClusterTask"),
+				Usage: "comma separated list of resource kinds to document",
+			},
+			&cli.StringFlag{
+				Name:  "task-template",
+				Usage: "path to a custom Go template to render Task/ClusterTask resources with",
+			},
+			&cli.StringFlag{
+				Name:  "pipeline-template",
+				Usage: "path to a custom Go template to render Pipeline resources with",
+			},
+			&cli.StringFlag{
+				Name:  "lang",
+				Usage: "BCP-47 language tag for generated section headings (defaults to $LANG)",
+			},
+			&cli.StringSliceFlag{
+				Name:     "source",
+				Required: true,
+				Usage:    "kustomize directory, plain YAML file, or HTTP(S) URL to a Tekton catalog manifest (repeatable)",
+			},
+			&cli.BoolFlag{
+				Name:  "stdout",
+				Usage: "write one concatenated document to stdout instead of a directory",
+			},
+		},
+		Action: runGenerate,
+	}
+}
+
+func runGenerate(c *cli.Context) error {
+	if format := c.String("format"); format != "markdown" {
+		return fmt.Errorf("format %q is not supported yet", format)
+	}
+
+	source, err := ResolveSources(c.StringSlice("source"))
+	if err != nil {
+		return fmt.Errorf("resolve source: %w", err)
+	}
+
+	resourceMap, err := source.Resources()
+	if err != nil {
+		return fmt.Errorf("load resources: %w", err)
+	}
+
+	includeKinds := parseIncludeKinds(c.StringSlice("include-kinds"))
+
+	tasks, err := GetAllTasksFromResourceMap(resourceMap, includeKinds)
+	if err != nil {
+		return fmt.Errorf("read tasks: %w", err)
+	}
+
+	pipelines, err := GetAllPipelinesFromResourceMap(resourceMap, includeKinds)
+	if err != nil {
+		return fmt.Errorf("read pipelines: %w", err)
+	}
+
+	taskTemplatePath := c.String("task-template")
+	pipelineTemplatePath := c.String("pipeline-template")
+	lang := ActiveLanguage(c.String("lang"))
+
+	slog.Debug("generating documentation", "tasks", len(tasks), "pipelines", len(pipelines), "lang", lang)
+
+	if c.Bool("stdout") {
+		return writeGeneratedMarkdownToStdout(tasks, pipelines, taskTemplatePath, pipelineTemplatePath, lang)
+	}
+
+	return GenerateMarkdownToDirectory(tasks, pipelines, c.String("output-dir"), taskTemplatePath, pipelineTemplatePath, lang)
+}
+
+func KustomizeBuild(path string) (resmap.ResMap, error) {
+	options := krusty.MakeDefaultOptions()
+	k := krusty.MakeKustomizer(options)
+	fs := filesys.FileSystemOrOnDisk{
+		FileSystem: nil,
+	}
+
+	resourceMap, err := k.Run(fs, path)
+	if err != nil {
+		return nil, fmt.Errorf("kustomize build %q: %w", path, err)
+	}
+
+	return resourceMap, nil
+}
+
+// GetAllTasksFromResourceMap decodes every Task and ClusterTask resource
+// present in includeKinds. Task and ClusterTask share the same spec shape,
+// so both decode into pipepinev1.Task.
+func GetAllTasksFromResourceMap(resourceMap resmap.ResMap, includeKinds map[string]bool) ([]pipepinev1.Task, error) {
+	tasks := make([]pipepinev1.Task, 0)
+
+	for _, res := range resourceMap.Resources() {
+		kind := res.GetKind()
+		if kind != "Task" && kind != "ClusterTask" {
+			continue
+		}
+
+		if !includeKinds[kind] {
+			continue
+		}
+
+		var task pipepinev1.Task
+
+		err := ResourceToType(res, &task)
+		if err != nil {
+			return tasks, fmt.Errorf("decode task %q: %w", res.GetName(), err)
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+func ResourceToType[T any](resource *resource.Resource, t *T) error {
+	bytes, err := resource.MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(bytes, &t)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func GenerateMarkdownToDirectory(tasks []pipepinev1.Task, pipelines []pipepinev1.Pipeline, outputDir, taskTemplatePath, pipelineTemplatePath, lang string) error {
+	err := os.MkdirAll(outputDir, os.ModePerm)
+	if err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		stringBuilder, err := renderTaskMarkdown(&task, taskTemplatePath, lang)
+		if err != nil {
+			return fmt.Errorf("render task %q: %w", task.Name, err)
+		}
+
+		err = writeGenerateMarkdown(&task, stringBuilder, outputDir)
+		if err != nil {
+			return fmt.Errorf("write task %q: %w", task.Name, err)
+		}
+	}
+
+	renderedTasks := renderedTaskNames(tasks)
+
+	for _, pipeline := range pipelines {
+		stringBuilder, err := renderPipelineMarkdown(&pipeline, renderedTasks, pipelineTemplatePath, lang)
+		if err != nil {
+			return fmt.Errorf("render pipeline %q: %w", pipeline.Name, err)
+		}
+
+		err = writePipelineMarkdown(&pipeline, stringBuilder, outputDir)
+		if err != nil {
+			return fmt.Errorf("write pipeline %q: %w", pipeline.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func writeGeneratedMarkdownToStdout(tasks []pipepinev1.Task, pipelines []pipepinev1.Pipeline, taskTemplatePath, pipelineTemplatePath, lang string) error {
+	for _, task := range tasks {
+		stringBuilder, err := renderTaskMarkdown(&task, taskTemplatePath, lang)
+		if err != nil {
+			return fmt.Errorf("render task %q: %w", task.Name, err)
+		}
+
+		_, err = fmt.Fprint(os.Stdout, stringBuilder.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	renderedTasks := renderedTaskNames(tasks)
+
+	for _, pipeline := range pipelines {
+		stringBuilder, err := renderPipelineMarkdown(&pipeline, renderedTasks, pipelineTemplatePath, lang)
+		if err != nil {
+			return fmt.Errorf("render pipeline %q: %w", pipeline.Name, err)
+		}
+
+		_, err = fmt.Fprint(os.Stdout, stringBuilder.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func renderedTaskNames(tasks []pipepinev1.Task) map[string]bool {
+	names := make(map[string]bool, len(tasks))
+	for _, task := range tasks {
+		names[task.Name] = true
+	}
+
+	return names
+}
+
+func renderTaskMarkdown(task *pipepinev1.Task, templatePath, lang string) (*strings.Builder, error) {
+	tmpl, err := loadTemplate(templatePath, defaultTaskTemplate, baseFuncMap(lang))
+	if err != nil {
+		return nil, err
+	}
+
+	return renderWithTemplate(tmpl, task)
+}
+
+func writeGenerateMarkdown(task *pipepinev1.Task, stringBuilder *strings.Builder, outputDir string) error {
+	path, err := safeMarkdownPath(outputDir, task.Name)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.WriteString(stringBuilder.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// safeMarkdownPath builds the "<outputDir>/<name>.md" path for a rendered
+// resource, rejecting names that would escape outputDir. Resource names
+// come straight from metadata.name, and with HTTPSource that metadata can
+// originate from an arbitrary remote manifest, so a name like
+// "../../etc/cron.d/evil" must not be allowed to write outside outputDir.
+func safeMarkdownPath(outputDir, name string) (string, error) {
+	if name == "" || name == "." || name == ".." || name != filepath.Base(name) {
+		return "", fmt.Errorf("invalid resource name %q: must not contain path separators", name)
+	}
+
+	path := filepath.Join(outputDir, name+".md")
+
+	cleanOutputDir := filepath.Clean(outputDir)
+	if path != cleanOutputDir && !strings.HasPrefix(path, cleanOutputDir+string(filepath.Separator)) {
+		return "", fmt.Errorf("invalid resource name %q: escapes output directory", name)
+	}
+
+	return path, nil
+}
+
+func stringifyParam(param *pipepinev1.ParamValue) (string, error) {
+	if param == nil {
+		return "", fmt.Errorf("cannot stringify nil param value")
+	}
+
+	switch param.Type {
+	case "string":
+		return yamlFlowScalar(param.StringVal), nil
+	case "array":
+		var stringBuilder strings.Builder
+		_, err := stringBuilder.WriteString("[")
+		if err != nil {
+			return "", err
+		}
+
+		var formatString string
+
+		for i, s := range param.ArrayVal {
+			if i == len(param.ArrayVal)-1 {
+				formatString = "%v"
+			} else {
+				formatString = "%v, "
+			}
+
+			_, err := stringBuilder.WriteString(fmt.Sprintf(formatString, yamlFlowScalar(s)))
+			if err != nil {
+				return "", err
+			}
+		}
+
+		_, err = stringBuilder.WriteString("]")
+		if err != nil {
+			return "", err
+		}
+
+		return stringBuilder.String(), nil
+	case "object":
+		var stringBuilder strings.Builder
+		_, err := stringBuilder.WriteString("{")
+		if err != nil {
+			return "", err
+		}
+
+		keys := make([]string, 0, len(param.ObjectVal))
+		for key := range param.ObjectVal {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for i, key := range keys {
+			formatString := "%v: %v, "
+			if i == len(keys)-1 {
+				formatString = "%v: %v"
+			}
+
+			_, err := stringBuilder.WriteString(fmt.Sprintf(formatString, yamlFlowScalar(key), yamlFlowScalar(param.ObjectVal[key])))
+			if err != nil {
+				return "", err
+			}
+		}
+
+		_, err = stringBuilder.WriteString("}")
+		if err != nil {
+			return "", err
+		}
+
+		return stringBuilder.String(), nil
+	}
+
+	return "", nil
+}
+
+// yamlFlowScalar renders s as a scalar that is safe to embed in a YAML flow
+// sequence/mapping or after a "key: " marker. Plain values are left
+// untouched; anything containing characters significant to YAML (colons,
+// commas, brackets, quotes, leading/trailing whitespace, and the like) is
+// double-quoted with Go's escaping rules, which double-quoted YAML scalars
+// also use.
+func yamlFlowScalar(s string) string {
+	if s == "" || yamlNeedsQuoting(s) {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
+
+func yamlNeedsQuoting(s string) bool {
+	if strings.ContainsAny(s, "\"'#,[]{}\n") {
+		return true
+	}
+
+	if strings.TrimSpace(s) != s {
+		return true
+	}
+
+	if strings.Contains(s, ": ") || strings.HasSuffix(s, ":") {
+		return true
+	}
+
+	switch s[0] {
+	case '-', '?', ':', '&', '*', '!', '|', '>', '%', '@', '`':
+		return true
+	}
+
+	return false
+}
+
+// generateUsageExample builds a ready-to-apply TaskRun skeleton: every
+// param is filled with its default, or a <REQUIRED> placeholder when it
+// has none, and every workspace is stubbed with an emptyDir.
+func generateUsageExample(task *pipepinev1.Task) (string, error) {
+	stringBuilder := strings.Builder{}
+
+	_, err := stringBuilder.WriteString("```yaml\n")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = stringBuilder.WriteString("apiVersion: tekton.dev/v1\nkind: TaskRun\n")
+	if err != nil {
+		return "", err
+	}
+
+	_, err = stringBuilder.WriteString(fmt.Sprintf("metadata:\n  generateName: %v-\n", task.Name))
+	if err != nil {
+		return "", err
+	}
+
+	_, err = stringBuilder.WriteString(fmt.Sprintf("spec:\n  taskRef:\n    name: %v\n", task.Name))
+	if err != nil {
+		return "", err
+	}
+
+	if len(task.Spec.Params) > 0 {
+		_, err = stringBuilder.WriteString("  params:\n")
+		if err != nil {
+			return "", err
+		}
+
+		for _, param := range task.Spec.Params {
+			value := "<REQUIRED>"
+
+			if param.Default != nil {
+				value, err = stringifyParam(param.Default)
+				if err != nil {
+					return "", err
+				}
+			}
+
+			_, err = stringBuilder.WriteString(fmt.Sprintf("    - name: %v\n      value: %v\n", param.Name, value))
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	if len(task.Spec.Workspaces) > 0 {
+		_, err = stringBuilder.WriteString("  workspaces:\n")
+		if err != nil {
+			return "", err
+		}
+
+		for _, workspace := range task.Spec.Workspaces {
+			_, err = stringBuilder.WriteString(fmt.Sprintf("    - name: %v\n      emptyDir: {}\n", workspace.Name))
+			if err != nil {
+				return "", err
+			}
+		}
+	}
+
+	_, err = stringBuilder.WriteString("```\n")
+	if err != nil {
+		return "", err
+	}
+
+	return stringBuilder.String(), nil
+}
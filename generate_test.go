@@ -0,0 +1,151 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	pipepinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+func TestStringifyParam(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   *pipepinev1.ParamValue
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "string",
+			param: &pipepinev1.ParamValue{Type: "string", StringVal: "hello"},
+			want:  "hello",
+		},
+		{
+			name:  "array",
+			param: &pipepinev1.ParamValue{Type: "array", ArrayVal: []string{"a", "b", "c"}},
+			want:  "[a, b, c]",
+		},
+		{
+			name:  "object",
+			param: &pipepinev1.ParamValue{Type: "object", ObjectVal: map[string]string{"b": "2", "a": "1"}},
+			want:  "{a: 1, b: 2}",
+		},
+		{
+			name:  "string needing quotes",
+			param: &pipepinev1.ParamValue{Type: "string", StringVal: "hello: world"},
+			want:  `"hello: world"`,
+		},
+		{
+			name:  "array element needing quotes",
+			param: &pipepinev1.ParamValue{Type: "array", ArrayVal: []string{"a: b", "c"}},
+			want:  `["a: b", c]`,
+		},
+		{
+			name:  "object value needing quotes",
+			param: &pipepinev1.ParamValue{Type: "object", ObjectVal: map[string]string{"a": "1, 2"}},
+			want:  `{a: "1, 2"}`,
+		},
+		{
+			name:    "nil",
+			param:   nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := stringifyParam(tt.param)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("stringifyParam() expected an error, got nil")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("stringifyParam() unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Errorf("stringifyParam() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateUsageExample(t *testing.T) {
+	task := &pipepinev1.Task{}
+	task.Name = "greet"
+	task.Spec.Params = []pipepinev1.ParamSpec{
+		{
+			Name:    "required-param",
+			Default: nil,
+		},
+		{
+			Name: "optional-param",
+			Default: &pipepinev1.ParamValue{
+				Type:      "string",
+				StringVal: "world",
+			},
+		},
+		{
+			Name: "colon-param",
+			Default: &pipepinev1.ParamValue{
+				Type:      "string",
+				StringVal: "hello: world",
+			},
+		},
+	}
+
+	example, err := generateUsageExample(task)
+	if err != nil {
+		t.Fatalf("generateUsageExample() unexpected error: %v", err)
+	}
+
+	if !strings.Contains(example, "name: required-param\n      value: <REQUIRED>") {
+		t.Errorf("generateUsageExample() missing <REQUIRED> placeholder for param with no default:\n%s", example)
+	}
+
+	if !strings.Contains(example, "name: optional-param\n      value: world") {
+		t.Errorf("generateUsageExample() missing default value for param with a default:\n%s", example)
+	}
+
+	if !strings.Contains(example, `name: colon-param`+"\n      value: \"hello: world\"") {
+		t.Errorf("generateUsageExample() did not quote a default containing a colon:\n%s", example)
+	}
+}
+
+func TestSafeMarkdownPath(t *testing.T) {
+	tests := []struct {
+		name       string
+		taskName   string
+		wantErr    bool
+		wantSuffix string
+	}{
+		{name: "plain name", taskName: "greet", wantSuffix: "out/greet.md"},
+		{name: "empty name", taskName: "", wantErr: true},
+		{name: "dot", taskName: ".", wantErr: true},
+		{name: "parent traversal", taskName: "..", wantErr: true},
+		{name: "nested traversal", taskName: "../../etc/cron.d/evil", wantErr: true},
+		{name: "embedded separator", taskName: "sub/evil", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := safeMarkdownPath("out", tt.taskName)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("safeMarkdownPath(%q) expected an error, got path %q", tt.taskName, got)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("safeMarkdownPath(%q) unexpected error: %v", tt.taskName, err)
+			}
+
+			if !strings.HasSuffix(got, tt.wantSuffix) {
+				t.Errorf("safeMarkdownPath(%q) = %q, want suffix %q", tt.taskName, got, tt.wantSuffix)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"strings"
+
+	sigsyaml "sigs.k8s.io/yaml"
+)
+
+//go:embed locales/*.yaml
+var localeFS embed.FS
+
+const defaultLocale = "en"
+
+type locale map[string]string
+
+var locales = loadLocales()
+
+// loadLocales parses every embedded locales/*.yaml bundle, keyed by the
+// BCP-47 tag in its filename (locales/fr.yaml -> "fr"). Adding a new
+// language is just dropping in another file here, no code changes needed.
+func loadLocales() map[string]locale {
+	bundles := make(map[string]locale)
+
+	entries, err := localeFS.ReadDir("locales")
+	if err != nil {
+		return bundles
+	}
+
+	for _, entry := range entries {
+		content, err := localeFS.ReadFile("locales/" + entry.Name())
+		if err != nil {
+			continue
+		}
+
+		var bundle locale
+
+		err = sigsyaml.Unmarshal(content, &bundle)
+		if err != nil {
+			continue
+		}
+
+		lang := strings.TrimSuffix(entry.Name(), ".yaml")
+		bundles[lang] = bundle
+	}
+
+	return bundles
+}
+
+// Tr looks up key in lang's bundle, falling back to the default locale, and
+// formats the result with args using fmt.Sprintf verbs. If the key isn't
+// found anywhere, Tr returns the key itself.
+func Tr(lang, key string, args ...any) string {
+	if format, ok := locales[lang][key]; ok {
+		return fmt.Sprintf(format, args...)
+	}
+
+	if format, ok := locales[defaultLocale][key]; ok {
+		return fmt.Sprintf(format, args...)
+	}
+
+	return key
+}
+
+// ActiveLanguage resolves the language to render in: the --lang flag if
+// set, otherwise LANG, normalized from POSIX locale form (e.g.
+// "en_US.UTF-8") down to a bare BCP-47 primary tag ("en").
+func ActiveLanguage(flagValue string) string {
+	lang := flagValue
+	if lang == "" {
+		lang = os.Getenv("LANG")
+	}
+
+	lang = strings.SplitN(lang, ".", 2)[0]
+	lang = strings.SplitN(lang, "_", 2)[0]
+	lang = strings.ToLower(strings.TrimSpace(lang))
+
+	if lang == "" {
+		return defaultLocale
+	}
+
+	return lang
+}
@@ -0,0 +1,13 @@
+package main
+
+// parseIncludeKinds turns the --include-kinds flag values into a lookup set
+// so callers can gate resource handling with includeKinds[kind].
+func parseIncludeKinds(kinds []string) map[string]bool {
+	includeKinds := make(map[string]bool, len(kinds))
+
+	for _, kind := range kinds {
+		includeKinds[kind] = true
+	}
+
+	return includeKinds
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+func listCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "list",
+		Usage: "list the Tekton resources discovered in a source",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "include-kinds",
+				Value: cli.NewStringSlice("Task", "Pipeline", "ClusterTask"),
+				Usage: "comma separated list of resource kinds to list",
+			},
+			&cli.StringSliceFlag{
+				Name:     "source",
+				Required: true,
+				Usage:    "kustomize directory, plain YAML file, or HTTP(S) URL to a Tekton catalog manifest (repeatable)",
+			},
+		},
+		Action: runList,
+	}
+}
+
+func runList(c *cli.Context) error {
+	source, err := ResolveSources(c.StringSlice("source"))
+	if err != nil {
+		return fmt.Errorf("resolve source: %w", err)
+	}
+
+	resourceMap, err := source.Resources()
+	if err != nil {
+		return fmt.Errorf("load resources: %w", err)
+	}
+
+	includeKinds := parseIncludeKinds(c.StringSlice("include-kinds"))
+
+	for _, res := range resourceMap.Resources() {
+		kind := res.GetKind()
+		if kind != "Task" && kind != "ClusterTask" && kind != "Pipeline" {
+			continue
+		}
+
+		if !includeKinds[kind] {
+			continue
+		}
+
+		fmt.Printf("%v/%v\n", kind, res.GetName())
+	}
+
+	return nil
+}
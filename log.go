@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+// newLogger builds the structured logger used for diagnostics, configured
+// by the global --log-level and --log-format flags.
+func newLogger(level, format string) (*slog.Logger, error) {
+	var leveler slog.Level
+
+	switch level {
+	case "debug":
+		leveler = slog.LevelDebug
+	case "info":
+		leveler = slog.LevelInfo
+	case "warn":
+		leveler = slog.LevelWarn
+	case "error":
+		leveler = slog.LevelError
+	default:
+		return nil, fmt.Errorf("unknown log level %q", level)
+	}
+
+	opts := &slog.HandlerOptions{Level: leveler}
+
+	var handler slog.Handler
+
+	switch format {
+	case "text":
+		handler = slog.NewTextHandler(os.Stderr, opts)
+	case "json":
+		handler = slog.NewJSONHandler(os.Stderr, opts)
+	default:
+		return nil, fmt.Errorf("unknown log format %q", format)
+	}
+
+	return slog.New(handler), nil
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	pipepinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"sigs.k8s.io/kustomize/api/resmap"
+)
+
+const taskResultReferencePrefix = "$(tasks."
+const taskResultReferenceInfix = ".results."
+
+func GetAllPipelinesFromResourceMap(resourceMap resmap.ResMap, includeKinds map[string]bool) ([]pipepinev1.Pipeline, error) {
+	pipelines := make([]pipepinev1.Pipeline, 0)
+
+	if !includeKinds["Pipeline"] {
+		return pipelines, nil
+	}
+
+	for _, res := range resourceMap.Resources() {
+		if res.GetKind() != "Pipeline" {
+			continue
+		}
+
+		var pipeline pipepinev1.Pipeline
+
+		err := ResourceToType(res, &pipeline)
+		if err != nil {
+			return pipelines, fmt.Errorf("decode pipeline %q: %w", res.GetName(), err)
+		}
+
+		pipelines = append(pipelines, pipeline)
+	}
+
+	return pipelines, nil
+}
+
+// renderPipelineMarkdown renders a pipeline's parameters, workspaces, results,
+// ordered task list and a Mermaid DAG of its tasks. renderedTasks holds the
+// names of tasks that were documented in the same run, so pipeline task nodes
+// can link through to their own markdown file.
+func renderPipelineMarkdown(pipeline *pipepinev1.Pipeline, renderedTasks map[string]bool, templatePath, lang string) (*strings.Builder, error) {
+	tmpl, err := loadTemplate(templatePath, defaultPipelineTemplate, pipelineFuncMap(renderedTasks, lang))
+	if err != nil {
+		return nil, err
+	}
+
+	return renderWithTemplate(tmpl, pipeline)
+}
+
+func pipelineFuncMap(renderedTasks map[string]bool, lang string) template.FuncMap {
+	funcMap := baseFuncMap(lang)
+
+	funcMap["pipelineEdges"] = pipelineTaskEdges
+	funcMap["taskLink"] = func(task pipepinev1.PipelineTask) string {
+		// TaskRef is nil for tasks defined via an inline TaskSpec, so fall
+		// back to the pipeline task's own name in that case.
+		taskRefName := task.Name
+		if task.TaskRef != nil {
+			taskRefName = task.TaskRef.Name
+		}
+
+		if renderedTasks[taskRefName] {
+			return fmt.Sprintf("[%v](%v.md)", task.Name, taskRefName)
+		}
+
+		return task.Name
+	}
+
+	return funcMap
+}
+
+func writePipelineMarkdown(pipeline *pipepinev1.Pipeline, stringBuilder *strings.Builder, outputDir string) error {
+	path, err := safeMarkdownPath(outputDir, pipeline.Name)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	_, err = file.WriteString(stringBuilder.String())
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+type pipelineEdge struct {
+	From string
+	To   string
+}
+
+// pipelineTaskEdges computes the edges of a pipeline's task DAG: explicit
+// runAfter constraints, plus implicit ordering derived from
+// $(tasks.<name>.results.<result>) references in a task's params.
+func pipelineTaskEdges(pipeline *pipepinev1.Pipeline) []pipelineEdge {
+	seen := make(map[pipelineEdge]bool)
+	edges := make([]pipelineEdge, 0)
+
+	addEdge := func(from, to string) {
+		edge := pipelineEdge{From: from, To: to}
+		if seen[edge] {
+			return
+		}
+
+		seen[edge] = true
+		edges = append(edges, edge)
+	}
+
+	for _, task := range pipeline.Spec.Tasks {
+		for _, runAfter := range task.RunAfter {
+			addEdge(runAfter, task.Name)
+		}
+
+		for _, param := range task.Params {
+			for _, referencedTask := range referencedTaskNames(param.Value) {
+				addEdge(referencedTask, task.Name)
+			}
+		}
+	}
+
+	return edges
+}
+
+// referencedTaskNames scans a param value for $(tasks.<name>.results.*)
+// references and returns the names of the tasks it depends on.
+func referencedTaskNames(value pipepinev1.ParamValue) []string {
+	names := make([]string, 0)
+
+	switch value.Type {
+	case "array":
+		for _, s := range value.ArrayVal {
+			names = append(names, referencedTaskNamesInString(s)...)
+		}
+	case "object":
+		for _, s := range value.ObjectVal {
+			names = append(names, referencedTaskNamesInString(s)...)
+		}
+	default:
+		names = append(names, referencedTaskNamesInString(value.StringVal)...)
+	}
+
+	return names
+}
+
+func referencedTaskNamesInString(s string) []string {
+	names := make([]string, 0)
+
+	for {
+		start := strings.Index(s, taskResultReferencePrefix)
+		if start == -1 {
+			break
+		}
+
+		rest := s[start+len(taskResultReferencePrefix):]
+
+		dot := strings.Index(rest, ".")
+		if dot == -1 {
+			break
+		}
+
+		if strings.HasPrefix(rest[dot:], taskResultReferenceInfix) {
+			names = append(names, rest[:dot])
+		}
+
+		s = rest[dot:]
+	}
+
+	return names
+}
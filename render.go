@@ -0,0 +1,79 @@
+package main
+
+import (
+	"embed"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	pipepinev1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+)
+
+//go:embed templates/*.tmpl
+var defaultTemplates embed.FS
+
+const (
+	defaultTaskTemplate     = "templates/task.md.tmpl"
+	defaultPipelineTemplate = "templates/pipeline.md.tmpl"
+)
+
+// baseFuncMap holds the helpers available to every template, whether built
+// in or supplied via --task-template or --pipeline-template. lang selects the active locale for the
+// "tr", "optional" and "default" helpers.
+func baseFuncMap(lang string) template.FuncMap {
+	return template.FuncMap{
+		"stringifyParam": stringifyParam,
+		"optional":       func(isOptional bool) string { return tmplOptional(lang, isOptional) },
+		"default":        func(param *pipepinev1.ParamValue) (string, error) { return tmplDefault(lang, param) },
+		"join":           strings.Join,
+		"tr":             func(key string, args ...any) string { return Tr(lang, key, args...) },
+		"usageExample":   generateUsageExample,
+	}
+}
+
+func tmplOptional(lang string, isOptional bool) string {
+	if isOptional {
+		return Tr(lang, "param.optional")
+	}
+
+	return ""
+}
+
+func tmplDefault(lang string, param *pipepinev1.ParamValue) (string, error) {
+	if param == nil {
+		return "", nil
+	}
+
+	value, err := stringifyParam(param)
+	if err != nil {
+		return "", err
+	}
+
+	return Tr(lang, "param.default", value), nil
+}
+
+// loadTemplate parses the template at templatePath if one was given,
+// otherwise falls back to the built-in default at embeddedPath.
+func loadTemplate(templatePath, embeddedPath string, funcMap template.FuncMap) (*template.Template, error) {
+	if templatePath != "" {
+		return template.New(filepath.Base(templatePath)).Funcs(funcMap).ParseFiles(templatePath)
+	}
+
+	content, err := defaultTemplates.ReadFile(embeddedPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return template.New(filepath.Base(embeddedPath)).Funcs(funcMap).Parse(string(content))
+}
+
+func renderWithTemplate(tmpl *template.Template, data any) (*strings.Builder, error) {
+	stringBuilder := strings.Builder{}
+
+	err := tmpl.Execute(&stringBuilder, data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stringBuilder, nil
+}
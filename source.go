@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"sigs.k8s.io/kustomize/api/provider"
+	"sigs.k8s.io/kustomize/api/resmap"
+)
+
+// httpSourceTimeout bounds how long we'll wait on a single remote manifest
+// fetch, so a slow or unresponsive catalog host can't hang the CLI.
+const httpSourceTimeout = 30 * time.Second
+
+var httpSourceClient = &http.Client{Timeout: httpSourceTimeout}
+
+// Source resolves a user-supplied location into a set of Tekton resources.
+type Source interface {
+	Resources() (resmap.ResMap, error)
+}
+
+// KustomizeSource builds resources from a kustomize overlay directory.
+type KustomizeSource struct {
+	Path string
+}
+
+func (s KustomizeSource) Resources() (resmap.ResMap, error) {
+	return KustomizeBuild(s.Path)
+}
+
+// FileSource parses a single plain YAML file, streaming it through a
+// yaml.Decoder so that `---` sequences inside a scalar (e.g. a step
+// script) aren't mistaken for document separators.
+type FileSource struct {
+	Path string
+}
+
+func (s FileSource) Resources() (resmap.ResMap, error) {
+	content, err := os.ReadFile(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("read %q: %w", s.Path, err)
+	}
+
+	return parseYAMLDocuments(content)
+}
+
+// HTTPSource fetches a manifest over HTTP(S), caching the response on disk
+// under a key derived from the URL and revalidating it with its ETag.
+type HTTPSource struct {
+	URL string
+}
+
+func (s HTTPSource) Resources() (resmap.ResMap, error) {
+	content, err := fetchWithETagCache(s.URL)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %q: %w", s.URL, err)
+	}
+
+	return parseYAMLDocuments(content)
+}
+
+// CompositeSource unions the resources of several sources, so a user can
+// combine a local overlay with a remote catalog task in one invocation.
+type CompositeSource struct {
+	Sources []Source
+}
+
+func (s CompositeSource) Resources() (resmap.ResMap, error) {
+	result := resmap.New()
+
+	for _, source := range s.Sources {
+		resources, err := source.Resources()
+		if err != nil {
+			return nil, err
+		}
+
+		err = result.AppendAll(resources)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// ResolveSources turns the --source values into a single Source, detecting
+// plain YAML files and HTTP(S) URLs and falling back to kustomize for
+// everything else.
+func ResolveSources(paths []string) (Source, error) {
+	sources := make([]Source, 0, len(paths))
+
+	for _, path := range paths {
+		sources = append(sources, resolveSource(path))
+	}
+
+	if len(sources) == 1 {
+		return sources[0], nil
+	}
+
+	return CompositeSource{Sources: sources}, nil
+}
+
+func resolveSource(path string) Source {
+	switch {
+	case strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://"):
+		return HTTPSource{URL: path}
+	case strings.HasSuffix(path, ".yaml") || strings.HasSuffix(path, ".yml"):
+		return FileSource{Path: path}
+	default:
+		return KustomizeSource{Path: path}
+	}
+}
+
+// parseYAMLDocuments streams a multi-document YAML manifest one document at
+// a time. A real decoder is required here rather than splitting on "\n---":
+// that separator can legitimately occur inside a scalar field (e.g. a
+// Task's steps[].script), which would corrupt the document boundaries.
+func parseYAMLDocuments(content []byte) (resmap.ResMap, error) {
+	pvd := provider.NewDefaultDepProvider()
+	factory := resmap.NewFactory(pvd.GetResourceFactory())
+
+	result := resmap.New()
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+
+	for {
+		var document map[string]any
+
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if document == nil {
+			continue
+		}
+
+		jsonBytes, err := json.Marshal(document)
+		if err != nil {
+			return nil, err
+		}
+
+		docResMap, err := factory.NewResMapFromBytes(jsonBytes)
+		if err != nil {
+			return nil, err
+		}
+
+		err = result.AppendAll(docResMap)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+func fetchWithETagCache(url string) ([]byte, error) {
+	cacheDir, err := cacheDirectory()
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.MkdirAll(cacheDir, os.ModePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	key := cacheKey(url)
+	bodyPath := filepath.Join(cacheDir, key+".body")
+	etagPath := filepath.Join(cacheDir, key+".etag")
+
+	request, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if etag, err := os.ReadFile(etagPath); err == nil {
+		request.Header.Set("If-None-Match", string(etag))
+	}
+
+	response, err := httpSourceClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotModified {
+		return os.ReadFile(bodyPath)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %v fetching %q", response.StatusCode, url)
+	}
+
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	err = os.WriteFile(bodyPath, body, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	if etag := response.Header.Get("ETag"); etag != "" {
+		err = os.WriteFile(etagPath, []byte(etag), 0o644)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return body, nil
+}
+
+func cacheDirectory() (string, error) {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, "taskmd"), nil
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".cache", "taskmd"), nil
+}
+
+func cacheKey(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return hex.EncodeToString(sum[:])
+}
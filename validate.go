@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+func validateCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "validate",
+		Usage: "check that a source resolves to well-formed Tekton resources",
+		Flags: []cli.Flag{
+			&cli.StringSliceFlag{
+				Name:  "include-kinds",
+				Value: cli.NewStringSlice("Task", "Pipeline", "ClusterTask"),
+				Usage: "comma separated list of resource kinds to validate",
+			},
+			&cli.StringSliceFlag{
+				Name:     "source",
+				Required: true,
+				Usage:    "kustomize directory, plain YAML file, or HTTP(S) URL to a Tekton catalog manifest (repeatable)",
+			},
+		},
+		Action: runValidate,
+	}
+}
+
+func runValidate(c *cli.Context) error {
+	source, err := ResolveSources(c.StringSlice("source"))
+	if err != nil {
+		return fmt.Errorf("resolve source: %w", err)
+	}
+
+	resourceMap, err := source.Resources()
+	if err != nil {
+		return fmt.Errorf("load resources: %w", err)
+	}
+
+	includeKinds := parseIncludeKinds(c.StringSlice("include-kinds"))
+
+	tasks, err := GetAllTasksFromResourceMap(resourceMap, includeKinds)
+	if err != nil {
+		return err
+	}
+
+	pipelines, err := GetAllPipelinesFromResourceMap(resourceMap, includeKinds)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%v resource(s) parsed successfully\n", len(tasks)+len(pipelines))
+
+	return nil
+}